@@ -0,0 +1,110 @@
+// Package cache provides a generic, type-safe counterpart to the
+// package-level Cache in the repository root, avoiding interface{} type
+// assertions at call sites.
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Errors for cache
+const (
+	ErrKeyNotFound = "key not found"
+	ErrKeyExists   = "key already exists"
+	ErrExpired     = "key expired"
+)
+
+// cacheItem is a struct for cache item
+type cacheItem[T any] struct {
+	value      T
+	expiration int64
+}
+
+// Cache is a generic, type-safe in-memory cache parameterized by value type.
+type Cache[T any] struct {
+	data map[string]cacheItem[T]
+	mx   sync.RWMutex
+}
+
+// NewCache is a constructor for Cache
+func NewCache[T any](options ...func(*Cache[T])) *Cache[T] {
+	c := &Cache[T]{
+		data: make(map[string]cacheItem[T]),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Set is a method for setting key-value pair
+// If key already exists, and it's not expired, return error
+// If key already exists, but it's expired, set new value and return nil
+// If key doesn't exist, set new value and return nil
+// If ttl is 0, set value without expiration
+func (c *Cache[T]) Set(key string, value T, ttl int64) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	d, ok := c.data[key]
+	if ok {
+		if d.expiration == 0 || d.expiration > time.Now().Unix() {
+			return fmt.Errorf(ErrKeyExists)
+		}
+	}
+
+	var expiration int64
+
+	if ttl > 0 {
+		expiration = time.Now().Unix() + ttl
+	}
+
+	c.data[key] = cacheItem[T]{
+		value:      value,
+		expiration: expiration,
+	}
+	return nil
+}
+
+// Get is a method for getting value by key
+// If key doesn't exist, return error
+// If key exists, but it's expired, return error and delete key
+// If key exists and it's not expired, return value
+func (c *Cache[T]) Get(key string) (T, error) {
+	_, err := c.Has(key)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	return c.data[key].value, nil
+}
+
+// Has is a method for checking if key exists.
+// If key doesn't exist, return false.
+// If key exists, but it's expired, return false and delete key.
+// If key exists and it's not expired, return true.
+func (c *Cache[T]) Has(key string) (bool, error) {
+	c.mx.RLock()
+	d, ok := c.data[key]
+	c.mx.RUnlock()
+	if !ok {
+		return false, fmt.Errorf(ErrKeyNotFound)
+	}
+
+	if d.expiration != 0 && d.expiration < time.Now().Unix() {
+		c.mx.Lock()
+		delete(c.data, key)
+		c.mx.Unlock()
+		return false, fmt.Errorf(ErrExpired)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetGetHas(t *testing.T) {
+	c := NewCache[int]()
+
+	if _, err := c.Get("a"); err == nil {
+		t.Fatalf("expected Get on an absent key to return an error")
+	}
+
+	if err := c.Set("a", 1, 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+	if err := c.Set("a", 2, 0); err == nil {
+		t.Fatalf("expected Set to fail for an already-present, unexpired key")
+	}
+
+	v, err := c.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+	if v != 1 {
+		t.Fatalf("expected 1, got %v", v)
+	}
+
+	ok, err := c.Has("a")
+	if err != nil || !ok {
+		t.Fatalf("expected Has(a) to report true, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestSetExpiration(t *testing.T) {
+	c := NewCache[string]()
+
+	if err := c.Set("a", "v1", 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+
+	// Backdate the entry directly, since Set treats a non-positive ttl as
+	// "no expiration" rather than "already expired".
+	c.mx.Lock()
+	c.data["a"] = cacheItem[string]{value: "v1", expiration: time.Now().Unix() - 1}
+	c.mx.Unlock()
+
+	if _, err := c.Get("a"); err == nil {
+		t.Fatalf("expected Get on an already-expired key to return an error")
+	}
+
+	if err := c.Set("a", "v2", 0); err != nil {
+		t.Fatalf("expected Set to succeed over an expired key, got error: %v", err)
+	}
+
+	v, err := c.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+	if v != "v2" {
+		t.Fatalf("expected v2, got %v", v)
+	}
+}
+
+func TestSetConcurrentOnlyOneWins(t *testing.T) {
+	for trial := 0; trial < 200; trial++ {
+		c := NewCache[int]()
+
+		const n = 2
+		var wg sync.WaitGroup
+		wg.Add(n)
+
+		errs := make([]error, n)
+		for i := 0; i < n; i++ {
+			i := i
+			go func() {
+				defer wg.Done()
+				errs[i] = c.Set("k", i, 0)
+			}()
+		}
+		wg.Wait()
+
+		successes := 0
+		for _, err := range errs {
+			if err == nil {
+				successes++
+			}
+		}
+		if successes != 1 {
+			t.Fatalf("trial %d: expected exactly one concurrent Set to succeed, got %d", trial, successes)
+		}
+	}
+}
+
+func TestHasDeletesExpired(t *testing.T) {
+	c := NewCache[int]()
+
+	if err := c.Set("a", 1, 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+
+	c.mx.Lock()
+	c.data["a"] = cacheItem[int]{value: 1, expiration: time.Now().Unix() - 1}
+	c.mx.Unlock()
+
+	ok, err := c.Has("a")
+	if ok || err == nil {
+		t.Fatalf("expected Has(a) to report expired, got ok=%v err=%v", ok, err)
+	}
+
+	c.mx.RLock()
+	_, stillPresent := c.data["a"]
+	c.mx.RUnlock()
+	if stillPresent {
+		t.Fatalf("expected expired key to be deleted from data by Has")
+	}
+}
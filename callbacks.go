@@ -0,0 +1,25 @@
+package main
+
+// KeyAndValue pairs a cache key with its value, used when a batch of items
+// is evicted at once.
+type KeyAndValue struct {
+	Key   string
+	Value interface{}
+}
+
+// OnEvicted registers a callback fired with the key and value of each item
+// removed from the cache, whether via explicit Delete, TTL expiration
+// noticed by Has/Get, or a janitor sweep.
+func (c *Cache) OnEvicted(f func(key string, value interface{})) {
+	c.mx.Lock()
+	c.onEvicted = f
+	c.mx.Unlock()
+}
+
+// OnEvictedBulk registers a callback fired once per janitor sweep with all
+// items evicted during that pass.
+func (c *Cache) OnEvictedBulk(f func([]KeyAndValue)) {
+	c.mx.Lock()
+	c.onEvictedBulk = f
+	c.mx.Unlock()
+}
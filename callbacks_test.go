@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestDeleteFiresOnEvicted(t *testing.T) {
+	c := NewCache()
+
+	var gotKey string
+	var gotValue interface{}
+	c.OnEvicted(func(key string, value interface{}) {
+		gotKey = key
+		gotValue = value
+	})
+
+	if err := c.Set("a", "v", 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+
+	if err := c.Delete("a"); err != nil {
+		t.Fatalf("Delete(a) returned error: %v", err)
+	}
+
+	if gotKey != "a" || gotValue != "v" {
+		t.Fatalf("expected OnEvicted to fire with (a, v), got (%v, %v)", gotKey, gotValue)
+	}
+
+	if _, err := c.Has("a"); err == nil {
+		t.Fatalf("expected a to be gone after Delete")
+	}
+}
+
+func TestDeleteAbsentKeyReturnsError(t *testing.T) {
+	c := NewCache()
+
+	if err := c.Delete("missing"); err == nil {
+		t.Fatalf("expected Delete on an absent key to return an error")
+	}
+}
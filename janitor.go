@@ -0,0 +1,91 @@
+package main
+
+import "time"
+
+// janitor periodically purges expired items from a Cache in the background.
+type janitor struct {
+	interval time.Duration
+	stop     chan struct{}
+}
+
+// WithCleanupInterval configures the Cache to run a background janitor that
+// deletes expired items every d. Without this option, expired items are
+// only removed lazily when touched by Has, Get or Replace.
+func WithCleanupInterval(d time.Duration) func(*Cache) {
+	return func(c *Cache) {
+		j := &janitor{
+			interval: d,
+			stop:     make(chan struct{}),
+		}
+		c.janitor = j
+		go j.run(c)
+	}
+}
+
+// run scans c.data on each tick and deletes expired entries under the write lock.
+func (j *janitor) run(c *Cache) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.deleteExpired()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// deleteExpired removes all currently-expired items from the cache, firing
+// any registered eviction callbacks for the items removed in this pass.
+func (c *Cache) deleteExpired() {
+	now := time.Now().Unix()
+
+	c.mx.Lock()
+	var evicted []KeyAndValue
+	for k, el := range c.data {
+		it := el.Value.(*CacheItem)
+		if it.expiration != 0 && it.expiration < now {
+			c.ll.Remove(el)
+			delete(c.data, k)
+			evicted = append(evicted, KeyAndValue{Key: k, Value: it.value})
+		}
+	}
+	cb := c.onEvicted
+	bulkCb := c.onEvictedBulk
+	c.mx.Unlock()
+
+	if len(evicted) == 0 {
+		return
+	}
+
+	if cb != nil {
+		for _, kv := range evicted {
+			cb(kv.Key, kv.Value)
+		}
+	}
+
+	if bulkCb != nil {
+		bulkCb(evicted)
+	}
+}
+
+// Close stops the background janitor, if one was configured. Close is safe
+// to call multiple times and is a no-op if no cleanup interval was set.
+func (c *Cache) Close() {
+	c.mx.Lock()
+	j := c.janitor
+	c.janitor = nil
+	c.mx.Unlock()
+
+	if j == nil {
+		return
+	}
+
+	select {
+	case <-j.stop:
+	default:
+		close(j.stop)
+	}
+}
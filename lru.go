@@ -0,0 +1,40 @@
+package main
+
+// WithMaxItems bounds the cache to at most n items. Once the bound is
+// reached, upsert evicts the least-recently-used entry to make room for
+// the new one, firing any registered eviction callback.
+func WithMaxItems(n int) func(*Cache) {
+	return func(c *Cache) {
+		c.maxItems = n
+	}
+}
+
+// upsert inserts key unconditionally, or updates it in place if already
+// present, marking it most recently used either way, and reports the
+// least-recently-used entry evicted to stay within maxItems, if any. This
+// is the single insertion path shared by Set, Replace, Load, and Store, so
+// the capacity bound can't be bypassed by any of them. Callers must hold
+// c.mx for writing.
+func (c *Cache) upsert(key string, value interface{}, expiration int64) (evicted KeyAndValue, ok bool) {
+	if el, exists := c.data[key]; exists {
+		it := el.Value.(*CacheItem)
+		it.value = value
+		it.expiration = expiration
+		c.ll.MoveToFront(el)
+		return KeyAndValue{}, false
+	}
+
+	el := c.ll.PushFront(&CacheItem{key: key, value: value, expiration: expiration})
+	c.data[key] = el
+
+	if c.maxItems <= 0 || c.ll.Len() <= c.maxItems {
+		return KeyAndValue{}, false
+	}
+
+	back := c.ll.Back()
+	it := back.Value.(*CacheItem)
+	c.ll.Remove(back)
+	delete(c.data, it.key)
+
+	return KeyAndValue{Key: it.key, Value: it.value}, true
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheSetEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewCache(WithMaxItems(2))
+
+	var evicted []string
+	c.OnEvicted(func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	})
+
+	if err := c.Set("a", 1, 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+	if err := c.Set("b", 2, 0); err != nil {
+		t.Fatalf("Set(b) returned error: %v", err)
+	}
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+
+	if err := c.Set("c", 3, 0); err != nil {
+		t.Fatalf("Set(c) returned error: %v", err)
+	}
+
+	if len(c.data) != 2 {
+		t.Fatalf("expected 2 entries after eviction, got %d", len(c.data))
+	}
+	if _, err := c.Has("b"); err == nil {
+		t.Fatalf("expected b to have been evicted")
+	}
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Fatalf("expected OnEvicted to fire for b, got %v", evicted)
+	}
+}
+
+func TestObjectStoreUpdateRespectsMaxItems(t *testing.T) {
+	c := NewCache(WithMaxItems(2))
+	s := NewObjectStore(c)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := s.Update(key); err != nil {
+			t.Fatalf("Update(%s) returned error: %v", key, err)
+		}
+	}
+
+	if got := len(s.List()); got != 2 {
+		t.Fatalf("expected 2 entries after Update overflow, got %d", got)
+	}
+}
+
+func TestLoadRespectsMaxItems(t *testing.T) {
+	src := NewCache()
+	for _, key := range []string{"a", "b", "c"} {
+		if err := src.Set(key, key, 0); err != nil {
+			t.Fatalf("Set(%s) returned error: %v", key, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	dst := NewCache(WithMaxItems(2))
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if len(dst.data) != 2 {
+		t.Fatalf("expected 2 entries after Load overflow, got %d", len(dst.data))
+	}
+}
+
+func TestJanitorSweepFiresBulkCallback(t *testing.T) {
+	c := NewCache(WithCleanupInterval(10 * time.Millisecond))
+	defer c.Close()
+
+	var mu sync.Mutex
+	var batches [][]KeyAndValue
+	c.OnEvictedBulk(func(kvs []KeyAndValue) {
+		mu.Lock()
+		batches = append(batches, kvs)
+		mu.Unlock()
+	})
+
+	if err := c.Set("a", 1, 1); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+	if err := c.Set("b", 2, 1); err != nil {
+		t.Fatalf("Set(b) returned error: %v", err)
+	}
+
+	// Backdate both entries so the janitor's next tick sees them as expired.
+	c.mx.Lock()
+	for _, el := range c.data {
+		el.Value.(*CacheItem).expiration = time.Now().Unix() - 1
+	}
+	c.mx.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		done := len(batches) > 0
+		mu.Unlock()
+		if done {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 1 {
+		t.Fatalf("expected exactly one bulk callback batch, got %d", len(batches))
+	}
+	if len(batches[0]) != 2 {
+		t.Fatalf("expected both expired keys in one batch, got %d", len(batches[0]))
+	}
+}
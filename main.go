@@ -1,6 +1,7 @@
 package main
 
 import (
+	"container/list"
 	"fmt"
 	"sync"
 	"time"
@@ -11,18 +12,26 @@ const (
 	ErrKeyNotFound = "key not found"
 	ErrKeyExists   = "key already exists"
 	ErrExpired     = "key expired"
+	ErrNotNumeric  = "value is not numeric"
 )
 
 // CacheItem is a struct for cache item
 type CacheItem struct {
+	key        string
 	value      interface{}
 	expiration int64
 }
 
 // Cache is a struct for cache, Cache is a simple in-memory cache.
 type Cache struct {
-	data map[string]CacheItem //data map[string]interface{}
-	mx   sync.RWMutex
+	data          map[string]*list.Element // values are *CacheItem
+	ll            *list.List               // front = most recently used
+	maxItems      int
+	mx            sync.RWMutex
+	janitor       *janitor
+	onEvicted     func(key string, value interface{})
+	onEvictedBulk func([]KeyAndValue)
+	keyFunc       KeyFunc
 }
 
 // Cacher is an interface for cache
@@ -30,12 +39,18 @@ type Cacher interface {
 	Set(key string, value interface{}, ttl int64) error
 	Get(key string) (interface{}, error)
 	Has(key string) (bool, error)
+	Add(key string, value interface{}, ttl int64) error
+	Replace(key string, value interface{}, ttl int64) error
+	Increment(key string, delta int64) (int64, error)
+	Decrement(key string, delta int64) (int64, error)
+	IncrementFloat(key string, delta float64) (float64, error)
 }
 
 // NewCache is a constructor for Cache
 func NewCache(options ...func(*Cache)) *Cache {
 	c := &Cache{
-		data: make(map[string]CacheItem),
+		data: make(map[string]*list.Element),
+		ll:   list.New(),
 	}
 
 	for _, option := range options {
@@ -50,28 +65,32 @@ func NewCache(options ...func(*Cache)) *Cache {
 // If key already exists, but it's expired, set new value and return nil
 // If key doesn't exist, set new value and return nil
 // If ttl is 0, set value without expiration
+// If a max item count was configured via WithMaxItems and inserting this
+// key would exceed it, the least-recently-used key is evicted.
 func (c *Cache) Set(key string, value interface{}, ttl int64) error {
-	c.mx.RLock()
-	d, ok := c.data[key]
-	c.mx.RUnlock()
-	if ok {
-		if d.expiration == 0 || d.expiration > time.Now().Unix() {
+	c.mx.Lock()
+
+	if el, ok := c.data[key]; ok {
+		it := el.Value.(*CacheItem)
+		if it.expiration == 0 || it.expiration > time.Now().Unix() {
+			c.mx.Unlock()
 			return fmt.Errorf(ErrKeyExists)
 		}
 	}
 
 	var expiration int64
-
 	if ttl > 0 {
 		expiration = time.Now().Unix() + ttl
 	}
 
-	c.mx.Lock()
-	c.data[key] = CacheItem{
-		value:      value,
-		expiration: expiration,
-	}
+	evicted, didEvict := c.upsert(key, value, expiration)
+	cb := c.onEvicted
 	c.mx.Unlock()
+
+	if didEvict && cb != nil {
+		cb(evicted.Key, evicted.Value)
+	}
+
 	return nil
 }
 
@@ -86,35 +105,72 @@ func (c *Cache) Get(key string) (interface{}, error) {
 		return nil, err
 	}
 
-	// safe return?
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
-	return c.data[key].value, nil
+	el, ok := c.data[key]
+	if !ok {
+		return nil, fmt.Errorf(ErrKeyNotFound)
+	}
+
+	return el.Value.(*CacheItem).value, nil
 }
 
 // Has is a method for checking if key exists.
 // If key doesn't exist, return false.
 // If key exists, but it's expired, return false and delete key.
-// If key exists and it's not expired, return true.
+// If key exists and it's not expired, return true and mark it most recently used.
 func (c *Cache) Has(key string) (bool, error) {
-	c.mx.RLock()
-	d, ok := c.data[key]
-	c.mx.RUnlock()
+	c.mx.Lock()
+	el, ok := c.data[key]
 	if !ok {
+		c.mx.Unlock()
 		return false, fmt.Errorf(ErrKeyNotFound)
 	}
 
-	if d.expiration != 0 && d.expiration < time.Now().Unix() {
-		c.mx.Lock()
+	it := el.Value.(*CacheItem)
+	if it.expiration != 0 && it.expiration < time.Now().Unix() {
+		c.ll.Remove(el)
 		delete(c.data, key)
+		cb := c.onEvicted
 		c.mx.Unlock()
+
+		if cb != nil {
+			cb(key, it.value)
+		}
+
 		return false, fmt.Errorf(ErrExpired)
 	}
 
+	c.ll.MoveToFront(el)
+	c.mx.Unlock()
+
 	return true, nil
 }
 
+// Delete removes key from the cache. If an eviction callback is registered
+// via OnEvicted, it fires with the removed value.
+func (c *Cache) Delete(key string) error {
+	c.mx.Lock()
+	el, ok := c.data[key]
+	if !ok {
+		c.mx.Unlock()
+		return fmt.Errorf(ErrKeyNotFound)
+	}
+
+	it := el.Value.(*CacheItem)
+	c.ll.Remove(el)
+	delete(c.data, key)
+	cb := c.onEvicted
+	c.mx.Unlock()
+
+	if cb != nil {
+		cb(key, it.value)
+	}
+
+	return nil
+}
+
 // type testItem struct {
 // 	key   string
 // 	value interface{}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Add sets value for key, but only if key is absent or its previous entry
+// has expired; it returns ErrKeyExists otherwise. Add has the same
+// semantics as Set, exposed under a clearer name to remove the ambiguity
+// of Set silently overwriting expired entries.
+func (c *Cache) Add(key string, value interface{}, ttl int64) error {
+	return c.Set(key, value, ttl)
+}
+
+// Replace overwrites the value for key, but only if key is already present
+// and not expired; it returns ErrKeyNotFound otherwise.
+func (c *Cache) Replace(key string, value interface{}, ttl int64) error {
+	c.mx.Lock()
+
+	el, ok := c.data[key]
+	if !ok {
+		c.mx.Unlock()
+		return fmt.Errorf(ErrKeyNotFound)
+	}
+
+	it := el.Value.(*CacheItem)
+	if it.expiration != 0 && it.expiration < time.Now().Unix() {
+		c.ll.Remove(el)
+		delete(c.data, key)
+		cb := c.onEvicted
+		c.mx.Unlock()
+
+		if cb != nil {
+			cb(key, it.value)
+		}
+
+		return fmt.Errorf(ErrKeyNotFound)
+	}
+
+	var expiration int64
+	if ttl > 0 {
+		expiration = time.Now().Unix() + ttl
+	}
+
+	c.upsert(key, value, expiration)
+	c.mx.Unlock()
+
+	return nil
+}
+
+// Increment adds delta to the int64 value stored at key under a single
+// write-lock acquisition, so concurrent increments don't race, and returns
+// the updated value. It returns ErrNotNumeric if the stored value isn't an
+// int64 or int.
+func (c *Cache) Increment(key string, delta int64) (int64, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	el, ok := c.data[key]
+	if !ok {
+		return 0, fmt.Errorf(ErrKeyNotFound)
+	}
+
+	it := el.Value.(*CacheItem)
+	if it.expiration != 0 && it.expiration < time.Now().Unix() {
+		return 0, fmt.Errorf(ErrExpired)
+	}
+
+	switch v := it.value.(type) {
+	case int64:
+		v += delta
+		it.value = v
+		c.ll.MoveToFront(el)
+		return v, nil
+	case int:
+		nv := int64(v) + delta
+		it.value = int(nv)
+		c.ll.MoveToFront(el)
+		return nv, nil
+	default:
+		return 0, fmt.Errorf(ErrNotNumeric)
+	}
+}
+
+// Decrement subtracts delta from the int64 value stored at key and returns
+// the updated value. It has the same locking and error semantics as
+// Increment.
+func (c *Cache) Decrement(key string, delta int64) (int64, error) {
+	return c.Increment(key, -delta)
+}
+
+// IncrementFloat adds delta to the float64 value stored at key under a
+// single write-lock acquisition and returns the updated value. It returns
+// ErrNotNumeric if the stored value isn't a float64.
+func (c *Cache) IncrementFloat(key string, delta float64) (float64, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	el, ok := c.data[key]
+	if !ok {
+		return 0, fmt.Errorf(ErrKeyNotFound)
+	}
+
+	it := el.Value.(*CacheItem)
+	if it.expiration != 0 && it.expiration < time.Now().Unix() {
+		return 0, fmt.Errorf(ErrExpired)
+	}
+
+	v, ok := it.value.(float64)
+	if !ok {
+		return 0, fmt.Errorf(ErrNotNumeric)
+	}
+
+	v += delta
+	it.value = v
+	c.ll.MoveToFront(el)
+
+	return v, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAddFailsIfPresent(t *testing.T) {
+	c := NewCache()
+
+	if err := c.Add("a", 1, 0); err != nil {
+		t.Fatalf("Add(a) returned error: %v", err)
+	}
+	if err := c.Add("a", 2, 0); err == nil {
+		t.Fatalf("expected Add to fail for an already-present key")
+	}
+}
+
+func TestReplaceFailsIfAbsent(t *testing.T) {
+	c := NewCache()
+
+	if err := c.Replace("a", 1, 0); err == nil {
+		t.Fatalf("expected Replace to fail for an absent key")
+	}
+
+	if err := c.Add("a", 1, 0); err != nil {
+		t.Fatalf("Add(a) returned error: %v", err)
+	}
+	if err := c.Replace("a", 2, 0); err != nil {
+		t.Fatalf("Replace(a) returned error: %v", err)
+	}
+
+	v, err := c.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+	if v != 2 {
+		t.Fatalf("expected replaced value 2, got %v", v)
+	}
+}
+
+func TestIncrementDecrementConcurrent(t *testing.T) {
+	c := NewCache()
+	if err := c.Set("counter", int64(0), 0); err != nil {
+		t.Fatalf("Set(counter) returned error: %v", err)
+	}
+
+	const n = 100
+	var wg sync.WaitGroup
+	wg.Add(2 * n)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.Increment("counter", 1); err != nil {
+				t.Errorf("Increment returned error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := c.Decrement("counter", 1); err != nil {
+				t.Errorf("Decrement returned error: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	v, err := c.Get("counter")
+	if err != nil {
+		t.Fatalf("Get(counter) returned error: %v", err)
+	}
+	if v != int64(0) {
+		t.Fatalf("expected counter to settle at 0 after equal increments/decrements, got %v", v)
+	}
+}
+
+func TestIncrementFloat(t *testing.T) {
+	c := NewCache()
+	if err := c.Set("f", 1.5, 0); err != nil {
+		t.Fatalf("Set(f) returned error: %v", err)
+	}
+
+	got, err := c.IncrementFloat("f", 0.5)
+	if err != nil {
+		t.Fatalf("IncrementFloat returned error: %v", err)
+	}
+	if got != 2.0 {
+		t.Fatalf("expected 2.0, got %v", got)
+	}
+
+	if err := c.Set("s", "not numeric", 0); err != nil {
+		t.Fatalf("Set(s) returned error: %v", err)
+	}
+	if _, err := c.IncrementFloat("s", 1); err == nil {
+		t.Fatalf("expected IncrementFloat on a non-numeric value to return an error")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+)
+
+// gobItem mirrors CacheItem with exported fields, since gob cannot see
+// CacheItem's unexported value and expiration directly.
+type gobItem struct {
+	Value      interface{}
+	Expiration int64
+}
+
+// Register registers a concrete type with encoding/gob so that values of
+// that type can be stored as the interface{} payload of a CacheItem and
+// survive a Save/Load round-trip.
+func Register(value interface{}) {
+	gob.Register(value)
+}
+
+// Save serializes the full cache, including expirations, to w using
+// encoding/gob.
+func (c *Cache) Save(w io.Writer) error {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	items := make(map[string]gobItem, len(c.data))
+	for k, el := range c.data {
+		it := el.Value.(*CacheItem)
+		items[k] = gobItem{Value: it.value, Expiration: it.expiration}
+	}
+
+	return gob.NewEncoder(w).Encode(items)
+}
+
+// SaveFile is a convenience wrapper around Save that writes to the file at path.
+func (c *Cache) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Save(f)
+}
+
+// Load decodes items from r and merges them into the cache, skipping
+// entries that have already expired and preserving absolute expiration
+// timestamps so a restart doesn't reset TTLs.
+func (c *Cache) Load(r io.Reader) error {
+	items := make(map[string]gobItem)
+	if err := gob.NewDecoder(r).Decode(&items); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+
+	c.mx.Lock()
+	var evicted []KeyAndValue
+	for k, v := range items {
+		if v.Expiration != 0 && v.Expiration < now {
+			continue
+		}
+
+		if kv, ok := c.upsert(k, v.Value, v.Expiration); ok {
+			evicted = append(evicted, kv)
+		}
+	}
+	cb := c.onEvicted
+	c.mx.Unlock()
+
+	if cb != nil {
+		for _, kv := range evicted {
+			cb(kv.Key, kv.Value)
+		}
+	}
+
+	return nil
+}
+
+// LoadFile is a convenience wrapper around Load that reads from the file at path.
+func (c *Cache) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type customPayload struct {
+	Name  string
+	Count int
+}
+
+func TestSaveLoadRoundTripsRegisteredType(t *testing.T) {
+	Register(customPayload{})
+
+	src := NewCache()
+	want := customPayload{Name: "widget", Count: 3}
+	if err := src.Set("a", want, 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	dst := NewCache()
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	got, err := dst.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestLoadSkipsExpiredEntries(t *testing.T) {
+	src := NewCache()
+	if err := src.Set("live", "v", 0); err != nil {
+		t.Fatalf("Set(live) returned error: %v", err)
+	}
+	if err := src.Set("stale", "v", 1); err != nil {
+		t.Fatalf("Set(stale) returned error: %v", err)
+	}
+
+	// Backdate "stale" so it is already expired on disk.
+	src.mx.Lock()
+	src.data["stale"].Value.(*CacheItem).expiration = time.Now().Unix() - 1
+	src.mx.Unlock()
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	dst := NewCache()
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if _, err := dst.Has("live"); err != nil {
+		t.Fatalf("expected live to survive Load, got error: %v", err)
+	}
+	if _, err := dst.Has("stale"); err == nil {
+		t.Fatalf("expected stale to be skipped by Load since it had already expired")
+	}
+}
+
+func TestLoadPreservesAbsoluteExpiration(t *testing.T) {
+	src := NewCache()
+	if err := src.Set("a", "v", 1000); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+
+	src.mx.RLock()
+	wantExpiration := src.data["a"].Value.(*CacheItem).expiration
+	src.mx.RUnlock()
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	dst := NewCache()
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	dst.mx.RLock()
+	gotExpiration := dst.data["a"].Value.(*CacheItem).expiration
+	dst.mx.RUnlock()
+
+	if gotExpiration != wantExpiration {
+		t.Fatalf("expected Load to preserve absolute expiration %d, got %d", wantExpiration, gotExpiration)
+	}
+}
+
+func TestSaveFileLoadFileRoundTrip(t *testing.T) {
+	path := t.TempDir() + "/cache.gob"
+
+	src := NewCache()
+	if err := src.Set("a", "v", 0); err != nil {
+		t.Fatalf("Set(a) returned error: %v", err)
+	}
+	if err := src.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile returned error: %v", err)
+	}
+
+	dst := NewCache()
+	if err := dst.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+
+	v, err := dst.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+	if v != "v" {
+		t.Fatalf("expected v, got %v", v)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import "fmt"
+
+// KeyFunc computes the cache key for an arbitrary object.
+type KeyFunc func(obj interface{}) (string, error)
+
+// Keyed can be implemented by objects that know their own cache key, so
+// they work with DefaultKeyFunc without any extra configuration.
+type Keyed interface {
+	Key() string
+}
+
+// DefaultKeyFunc is a MetaNamespaceKeyFunc-style helper: it returns obj's
+// own key if obj implements Keyed, and otherwise derives one from its %v
+// representation.
+func DefaultKeyFunc(obj interface{}) (string, error) {
+	if k, ok := obj.(Keyed); ok {
+		return k.Key(), nil
+	}
+	return fmt.Sprintf("%v", obj), nil
+}
+
+// Store is implemented by caches that index arbitrary typed objects by a
+// key derived from the object itself, rather than requiring the caller to
+// compute keys manually.
+type Store interface {
+	Add(obj interface{}) error
+	Update(obj interface{}) error
+	Delete(obj interface{}) error
+	Get(obj interface{}) (interface{}, bool, error)
+	List() []interface{}
+	ListKeys() []string
+}
+
+// WithKeyFunc configures the KeyFunc a Cache uses when wrapped in an
+// ObjectStore. Without this option, DefaultKeyFunc is used.
+func WithKeyFunc(kf KeyFunc) func(*Cache) {
+	return func(c *Cache) {
+		c.keyFunc = kf
+	}
+}
+
+// ObjectStore adapts a Cache to the Store interface, deriving keys from the
+// objects themselves via the Cache's configured KeyFunc instead of
+// requiring the caller to compute them. This keeps Cache itself a thin,
+// string-keyed cache while letting callers layer per-object-type isolation
+// on top by wrapping separate Cache instances.
+type ObjectStore struct {
+	c *Cache
+}
+
+// NewObjectStore wraps c as a Store. If c wasn't configured with
+// WithKeyFunc, DefaultKeyFunc is used.
+func NewObjectStore(c *Cache) *ObjectStore {
+	if c.keyFunc == nil {
+		c.keyFunc = DefaultKeyFunc
+	}
+	return &ObjectStore{c: c}
+}
+
+// Add derives obj's key via the configured KeyFunc and stores it without
+// expiration, failing if the key is already present.
+func (s *ObjectStore) Add(obj interface{}) error {
+	key, err := s.c.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+	return s.c.Set(key, obj, 0)
+}
+
+// Update derives obj's key via the configured KeyFunc and overwrites any
+// existing entry for it, regardless of expiration. It goes through the
+// same capacity-aware upsert path as Cache.Set, so a Store built on a
+// WithMaxItems cache still respects the bound.
+func (s *ObjectStore) Update(obj interface{}) error {
+	key, err := s.c.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+
+	s.c.mx.Lock()
+	evicted, didEvict := s.c.upsert(key, obj, 0)
+	cb := s.c.onEvicted
+	s.c.mx.Unlock()
+
+	if didEvict && cb != nil {
+		cb(evicted.Key, evicted.Value)
+	}
+
+	return nil
+}
+
+// Delete derives obj's key via the configured KeyFunc and removes it.
+func (s *ObjectStore) Delete(obj interface{}) error {
+	key, err := s.c.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+	return s.c.Delete(key)
+}
+
+// Get derives obj's key via the configured KeyFunc and returns the stored
+// object, reporting false instead of an error if it isn't present.
+func (s *ObjectStore) Get(obj interface{}) (interface{}, bool, error) {
+	key, err := s.c.keyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v, err := s.c.Get(key)
+	if err != nil {
+		return nil, false, nil
+	}
+	return v, true, nil
+}
+
+// List returns every object currently stored.
+func (s *ObjectStore) List() []interface{} {
+	s.c.mx.RLock()
+	defer s.c.mx.RUnlock()
+
+	out := make([]interface{}, 0, len(s.c.data))
+	for _, el := range s.c.data {
+		out = append(out, el.Value.(*CacheItem).value)
+	}
+	return out
+}
+
+// ListKeys returns the derived key of every object currently stored.
+func (s *ObjectStore) ListKeys() []string {
+	s.c.mx.RLock()
+	defer s.c.mx.RUnlock()
+
+	out := make([]string, 0, len(s.c.data))
+	for k := range s.c.data {
+		out = append(out, k)
+	}
+	return out
+}
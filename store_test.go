@@ -0,0 +1,113 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+type keyedThing struct {
+	id   string
+	data int
+}
+
+func (k keyedThing) Key() string { return k.id }
+
+func TestDefaultKeyFuncUsesKeyed(t *testing.T) {
+	key, err := DefaultKeyFunc(keyedThing{id: "a", data: 1})
+	if err != nil {
+		t.Fatalf("DefaultKeyFunc returned error: %v", err)
+	}
+	if key != "a" {
+		t.Fatalf("expected key a, got %v", key)
+	}
+
+	key, err = DefaultKeyFunc(42)
+	if err != nil {
+		t.Fatalf("DefaultKeyFunc returned error: %v", err)
+	}
+	if key != "42" {
+		t.Fatalf("expected key 42, got %v", key)
+	}
+}
+
+func TestObjectStoreAddFailsIfPresent(t *testing.T) {
+	s := NewObjectStore(NewCache())
+
+	if err := s.Add(keyedThing{id: "a", data: 1}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if err := s.Add(keyedThing{id: "a", data: 2}); err == nil {
+		t.Fatalf("expected Add to fail for an already-present key")
+	}
+}
+
+func TestObjectStoreGet(t *testing.T) {
+	s := NewObjectStore(NewCache())
+
+	if _, ok, err := s.Get(keyedThing{id: "a"}); ok || err != nil {
+		t.Fatalf("expected Get on an absent key to report ok=false, err=nil, got ok=%v err=%v", ok, err)
+	}
+
+	want := keyedThing{id: "a", data: 1}
+	if err := s.Add(want); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	got, ok, err := s.Get(keyedThing{id: "a"})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected Get to report ok=true for a present key")
+	}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestObjectStoreDelete(t *testing.T) {
+	s := NewObjectStore(NewCache())
+
+	if err := s.Add(keyedThing{id: "a", data: 1}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if err := s.Delete(keyedThing{id: "a"}); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	if _, ok, err := s.Get(keyedThing{id: "a"}); ok || err != nil {
+		t.Fatalf("expected Get after Delete to report ok=false, err=nil, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestObjectStoreListAndListKeys(t *testing.T) {
+	s := NewObjectStore(NewCache())
+
+	things := []keyedThing{{id: "a", data: 1}, {id: "b", data: 2}, {id: "c", data: 3}}
+	for _, th := range things {
+		if err := s.Add(th); err != nil {
+			t.Fatalf("Add(%v) returned error: %v", th, err)
+		}
+	}
+
+	keys := s.ListKeys()
+	sort.Strings(keys)
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "c" {
+		t.Fatalf("expected keys [a b c], got %v", keys)
+	}
+
+	list := s.List()
+	if len(list) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(list))
+	}
+
+	seen := map[string]bool{}
+	for _, obj := range list {
+		seen[obj.(keyedThing).id] = true
+	}
+	for _, th := range things {
+		if !seen[th.id] {
+			t.Fatalf("expected List to include %v", th)
+		}
+	}
+}